@@ -0,0 +1,77 @@
+// Package config persists movcli's settings, search history, and watchlist
+// to disk so a session survives restarts, and can hot-reload the main
+// config file while the TUI is running.
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Config holds user-tunable settings read from config.json.
+type Config struct {
+	APIKeys           map[string]string `json:"api_keys"`
+	PreferredProvider string            `json:"preferred_provider"`
+	PlayerCommand     string            `json:"player_command"`
+}
+
+func defaultConfig() *Config {
+	return &Config{APIKeys: map[string]string{}}
+}
+
+// Dir returns ~/.config/movcli (or the OS equivalent), creating it if
+// necessary.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "movcli")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func path(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Load reads config.json, returning a default Config if it doesn't exist
+// yet.
+func Load() (*Config, error) {
+	p, err := path("config.json")
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return defaultConfig(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := defaultConfig()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Save writes c to config.json.
+func (c *Config) Save() error {
+	p, err := path("config.json")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
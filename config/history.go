@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// maxHistory bounds how many past searches we keep around.
+const maxHistory = 50
+
+// HistoryEntry is one past search.
+type HistoryEntry struct {
+	Query string    `json:"query"`
+	Time  time.Time `json:"time"`
+}
+
+// History is the saved list of past searches, most recent first.
+type History struct {
+	Entries []HistoryEntry `json:"entries"`
+}
+
+// LoadHistory reads history.json, returning an empty History if it doesn't
+// exist yet.
+func LoadHistory() (*History, error) {
+	p, err := path("history.json")
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return &History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	h := &History{}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Add records a search, most recent first, trimming to maxHistory.
+func (h *History) Add(query string) {
+	h.Entries = append([]HistoryEntry{{Query: query, Time: time.Now()}}, h.Entries...)
+	if len(h.Entries) > maxHistory {
+		h.Entries = h.Entries[:maxHistory]
+	}
+}
+
+// Save writes h to history.json.
+func (h *History) Save() error {
+	p, err := path("history.json")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch watches config.json for changes and sends the reloaded Config on
+// the returned channel on every edit, mirroring the Procurator TUI's
+// filesystem-watching approach so edits made from another terminal take
+// effect without restarting movcli. The channel is closed if the watcher
+// cannot keep running.
+func Watch() (<-chan *Config, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	out := make(chan *Config)
+	go func() {
+		defer watcher.Close()
+		defer close(out)
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(ev.Name) != "config.json" {
+					continue
+				}
+				if ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if cfg, err := Load(); err == nil {
+					out <- cfg
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// WatchlistEntry is one bookmarked item, tagged with the provider it came
+// from so it can be resolved again after a restart.
+type WatchlistEntry struct {
+	Provider string `json:"provider"`
+	Title    string `json:"title"`
+	Desc     string `json:"desc"`
+	URL      string `json:"url"`
+}
+
+// Watchlist is the saved list of bookmarked items.
+type Watchlist struct {
+	Entries []WatchlistEntry `json:"entries"`
+}
+
+// LoadWatchlist reads watchlist.json, returning an empty Watchlist if it
+// doesn't exist yet.
+func LoadWatchlist() (*Watchlist, error) {
+	p, err := path("watchlist.json")
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return &Watchlist{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	w := &Watchlist{}
+	if err := json.Unmarshal(data, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Add bookmarks e, ignoring duplicates by URL.
+func (w *Watchlist) Add(e WatchlistEntry) {
+	for _, existing := range w.Entries {
+		if existing.URL == e.URL {
+			return
+		}
+	}
+	w.Entries = append(w.Entries, e)
+}
+
+// Save writes w to watchlist.json.
+func (w *Watchlist) Save() error {
+	p, err := path("watchlist.json")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/JMJAJ/movcli/provider"
+)
+
+// detailResultMsg and detailErrMsg carry a detail fetch back to whichever
+// tab requested it, same tagging rationale as searchResultMsg.
+type detailResultMsg struct {
+	tab    int
+	detail provider.Detail
+	poster string
+}
+
+type detailErrMsg struct {
+	tab int
+	err error
+}
+
+// fetchDetailCmd fetches its detail page via the provider, if it supports
+// Detailer, and best-effort renders its poster as ASCII art via chafa.
+func fetchDetailCmd(p provider.Provider, tabIdx int, it provider.Item) tea.Cmd {
+	return func() tea.Msg {
+		d, ok := p.(provider.Detailer)
+		if !ok {
+			return detailErrMsg{tab: tabIdx, err: fmt.Errorf("%s: no detail view available", p.Name())}
+		}
+		detail, err := d.FetchDetail(it)
+		if err != nil {
+			return detailErrMsg{tab: tabIdx, err: err}
+		}
+		poster, _ := renderPosterASCII(detail.PosterURL, 28, 14)
+		return detailResultMsg{tab: tabIdx, detail: detail, poster: poster}
+	}
+}
+
+// renderPosterASCII downloads the poster and shells out to chafa to render
+// it as terminal art. It returns an error (and no art) if chafa isn't on
+// PATH or the poster can't be fetched; callers treat this as best-effort.
+func renderPosterASCII(posterURL string, width, height int) (string, error) {
+	if posterURL == "" {
+		return "", fmt.Errorf("no poster available")
+	}
+	if _, err := exec.LookPath("chafa"); err != nil {
+		return "", err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(posterURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp("", "movcli-poster-*.img")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command("chafa", "--size", fmt.Sprintf("%dx%d", width, height), tmp.Name()).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// buildDetailContent renders a Detail into the scrollable text shown inside
+// the detail viewport.
+func buildDetailContent(d provider.Detail, poster string) string {
+	var lines []string
+	if poster != "" {
+		lines = append(lines, poster, "")
+	}
+
+	title := d.Title
+	if d.Year != "" {
+		title = fmt.Sprintf("%s (%s)", d.Title, d.Year)
+	}
+	lines = append(lines, labelStyle.Render(title), "")
+
+	if d.Synopsis != "" {
+		lines = append(lines, normalTitleStyle.Render(d.Synopsis), "")
+	}
+	if len(d.Genres) > 0 {
+		lines = append(lines, labelStyle.Render("Genres"), normalTitleStyle.Render(strings.Join(d.Genres, ", ")), "")
+	}
+	if len(d.Cast) > 0 {
+		lines = append(lines, labelStyle.Render("Cast"), normalTitleStyle.Render(strings.Join(d.Cast, ", ")), "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m model) viewDetail() string {
+	t := m.tabs[m.activeTab]
+
+	header := listHeaderStyle.Render("DETAIL")
+	div := divStyle.Render(strings.Repeat("-", t.viewport.Width))
+
+	play := keyStyle.Render("P")
+	open := keyStyle.Render("O")
+	watch := keyStyle.Render("W")
+	esc := keyStyle.Render("ESC")
+	hints := hintStyle.Render(fmt.Sprintf("  %s play   %s open   %s watchlist   %s back", play, open, watch, esc))
+
+	var body string
+	if !t.detailLoaded {
+		body = loadStyle.Render(fmt.Sprintf("  %s  loading details for \"%s\"", m.spinner.View(), t.selected.TitleText))
+	} else {
+		body = t.viewport.View()
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left,
+			header,
+			div,
+			body,
+			div,
+			hints,
+		),
+	)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/JMJAJ/movcli/scraper"
+)
+
+// errorLabel picks the stateError heading for err's scraper error kind, so
+// "no results" reads differently from a dropped connection.
+func errorLabel(err error) string {
+	switch {
+	case errors.Is(err, scraper.ErrRateLimited):
+		return "RATE LIMITED"
+	case errors.Is(err, scraper.ErrNetwork):
+		return "NETWORK ERROR"
+	case errors.Is(err, scraper.ErrParseFailed):
+		return "PARSE ERROR"
+	case errors.Is(err, scraper.ErrNoResults):
+		return "NO RESULTS"
+	default:
+		return "ERROR"
+	}
+}
+
+// canRetry reports whether err is transient enough that re-issuing the
+// same search or detail fetch is worth offering.
+func canRetry(err error) bool {
+	return errors.Is(err, scraper.ErrNetwork) || errors.Is(err, scraper.ErrRateLimited)
+}
+
+// canReport reports whether err carries the raw HTML a markup change broke
+// on, worth saving for a bug report.
+func canReport(err error) bool {
+	var perr *scraper.ParseError
+	return errors.As(err, &perr)
+}
+
+// dumpFailedHTML saves html to ~/.cache/movcli/failed-<timestamp>.html and
+// returns its path, so a parse failure can be attached to a bug report
+// instead of needing to be reproduced live.
+func dumpFailedHTML(html string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "movcli")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("failed-%d.html", time.Now().UnixNano()))
+	if err := os.WriteFile(path, []byte(html), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
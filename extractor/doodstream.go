@@ -0,0 +1,43 @@
+package extractor
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// doodstream extracts the pass_md5 path from a doodstream embed page and
+// returns the body that endpoint serves, trimmed, as the direct link. No
+// token or expiry is appended to that link.
+type doodstream struct{}
+
+func init() { Register(doodstream{}) }
+
+func (doodstream) Matches(embedURL string) bool {
+	return strings.Contains(embedURL, "dood.") || strings.Contains(embedURL, "doodstream.")
+}
+
+var doodTokenRe = regexp.MustCompile(`\$\.get\('(/pass_md5/[^']+)'`)
+
+func (doodstream) Extract(embedURL string) (string, map[string]string, error) {
+	body, err := fetch(embedURL)
+	if err != nil {
+		return "", nil, err
+	}
+	m := doodTokenRe.FindStringSubmatch(body)
+	if m == nil {
+		return "", nil, fmt.Errorf("doodstream: pass_md5 token not found in embed page")
+	}
+
+	u, err := url.Parse(embedURL)
+	if err != nil {
+		return "", nil, err
+	}
+	base := u.Scheme + "://" + u.Host
+	direct, err := fetch(base + m[1])
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimSpace(direct), map[string]string{"Referer": embedURL}, nil
+}
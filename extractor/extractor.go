@@ -0,0 +1,33 @@
+// Package extractor pulls a direct, playable stream URL out of the embed
+// pages that scraped movie sites iframe in. Each host gets its own small
+// Extractor so new hosts are a self-registering file, not a change to the
+// scraper or the UI.
+package extractor
+
+// Extractor knows how to pull a direct stream URL out of one embed host's
+// page.
+type Extractor interface {
+	// Matches reports whether this extractor handles embedURL.
+	Matches(embedURL string) bool
+	// Extract returns the direct stream URL and any headers (e.g. Referer)
+	// the stream host requires when the URL is requested.
+	Extract(embedURL string) (streamURL string, headers map[string]string, err error)
+}
+
+var registry []Extractor
+
+// Register adds e to the set consulted by For. Extractors register
+// themselves from an init() in their own file.
+func Register(e Extractor) {
+	registry = append(registry, e)
+}
+
+// For returns the first registered extractor that handles embedURL.
+func For(embedURL string) (Extractor, bool) {
+	for _, e := range registry {
+		if e.Matches(embedURL) {
+			return e, true
+		}
+	}
+	return nil, false
+}
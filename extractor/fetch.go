@@ -0,0 +1,36 @@
+package extractor
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/JMJAJ/movcli/useragent"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// fetch retrieves embedURL's body as a string, rotating through a
+// realistic header set so embed hosts don't bounce every extractor
+// request for looking like the same bot.
+func fetch(embedURL string) (string, error) {
+	req, err := http.NewRequest("GET", embedURL, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range useragent.Pick() {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
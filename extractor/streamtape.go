@@ -0,0 +1,31 @@
+package extractor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// streamtape extracts the direct mp4 URL streamtape assembles client-side
+// from two split strings to frustrate naive scrapers.
+type streamtape struct{}
+
+func init() { Register(streamtape{}) }
+
+func (streamtape) Matches(embedURL string) bool {
+	return strings.Contains(embedURL, "streamtape.")
+}
+
+var streamtapeRe = regexp.MustCompile(`id="ideoooolink"[^>]*>([^<]+)<.*?innerHTML\s*=\s*"([^"]*)"\s*\+\s*\('([^']*)'\)`)
+
+func (streamtape) Extract(embedURL string) (string, map[string]string, error) {
+	body, err := fetch(embedURL)
+	if err != nil {
+		return "", nil, err
+	}
+	m := streamtapeRe.FindStringSubmatch(body)
+	if m == nil {
+		return "", nil, fmt.Errorf("streamtape: stream url not found in embed page")
+	}
+	return "https:" + m[2] + m[3], map[string]string{"Referer": embedURL}, nil
+}
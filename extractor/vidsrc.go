@@ -0,0 +1,31 @@
+package extractor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// vidsrc extracts the m3u8 URL vidsrc's player embeds as a `file:` entry
+// in its inline player config.
+type vidsrc struct{}
+
+func init() { Register(vidsrc{}) }
+
+func (vidsrc) Matches(embedURL string) bool {
+	return strings.Contains(embedURL, "vidsrc.")
+}
+
+var vidsrcFileRe = regexp.MustCompile(`file\s*:\s*"([^"]+\.m3u8[^"]*)"`)
+
+func (vidsrc) Extract(embedURL string) (string, map[string]string, error) {
+	body, err := fetch(embedURL)
+	if err != nil {
+		return "", nil, err
+	}
+	m := vidsrcFileRe.FindStringSubmatch(body)
+	if m == nil {
+		return "", nil, fmt.Errorf("vidsrc: stream url not found in embed page")
+	}
+	return m[1], map[string]string{"Referer": embedURL}, nil
+}
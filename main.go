@@ -1,418 +1,856 @@
-package main
-
-import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"os"
-	"os/exec"
-	"regexp"
-	"runtime"
-	"strings"
-	"time"
-
-	"github.com/charmbracelet/bubbles/list"
-	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/textinput"
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
-)
-
-// -- Palette ------------------------------------------------------------------
-var (
-	clrYellow = lipgloss.Color("#F5E642")
-	clrWhite  = lipgloss.Color("#EEEEEE")
-	clrGray   = lipgloss.Color("#888888")
-	clrDark   = lipgloss.Color("#444444")
-	clrBlack  = lipgloss.Color("#111111")
-)
-
-// -- Styles -------------------------------------------------------------------
-var (
-	outerStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(clrWhite).
-			Padding(1, 3).
-			Width(58)
-
-	logoStyle = lipgloss.NewStyle().
-			Foreground(clrYellow).
-			Bold(true)
-
-	subStyle = lipgloss.NewStyle().
-			Foreground(clrGray)
-
-	labelStyle = lipgloss.NewStyle().
-			Foreground(clrYellow).
-			Bold(true)
-
-	divStyle = lipgloss.NewStyle().
-			Foreground(clrDark)
-
-	hintStyle = lipgloss.NewStyle().
-			Foreground(clrGray)
-
-	keyStyle = lipgloss.NewStyle().
-			Foreground(clrBlack).
-			Background(clrYellow).
-			Bold(true).
-			Padding(0, 1)
-
-	loadStyle = lipgloss.NewStyle().
-			Foreground(clrWhite).
-			Bold(true)
-
-	errStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(clrYellow).
-			Foreground(clrWhite).
-			Padding(1, 3)
-
-	selectedTitleStyle = lipgloss.NewStyle().
-				Foreground(clrYellow).
-				Bold(true)
-
-	normalTitleStyle = lipgloss.NewStyle().
-				Foreground(clrWhite)
-
-	selectedDescStyle = lipgloss.NewStyle().
-				Foreground(clrGray)
-
-	normalDescStyle = lipgloss.NewStyle().
-			Foreground(clrDark)
-
-	listHeaderStyle = lipgloss.NewStyle().
-			Foreground(clrBlack).
-			Background(clrYellow).
-			Bold(true).
-			Padding(0, 2)
-
-	countStyle = lipgloss.NewStyle().
-			Foreground(clrGray)
-)
-
-// -- Session state ------------------------------------------------------------
-type sessionState int
-
-const (
-	stateSearch sessionState = iota
-	stateLoading
-	stateResults
-	stateError
-)
-
-// -- List item ----------------------------------------------------------------
-type item struct {
-	title, desc, itemURL string
-}
-
-func (i item) Title() string       { return i.title }
-func (i item) Description() string { return i.desc }
-func (i item) FilterValue() string { return i.title }
-
-// -- Custom delegate ----------------------------------------------------------
-type delegate struct{}
-
-func (d delegate) Height() int                             { return 2 }
-func (d delegate) Spacing() int                            { return 1 }
-func (d delegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
-func (d delegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
-	i, ok := listItem.(item)
-	if !ok {
-		return
-	}
-	sel := index == m.Index()
-	prefix := "  "
-	titleS := normalTitleStyle.Render(i.title)
-	descS := normalDescStyle.Render(i.desc)
-	if sel {
-		prefix = selectedTitleStyle.Render("> ")
-		titleS = selectedTitleStyle.Render(i.title)
-		descS = selectedDescStyle.Render(i.desc)
-	}
-	fmt.Fprintf(w, "%s%s\n  %s", prefix, titleS, descS)
-}
-
-// -- App model ----------------------------------------------------------------
-type model struct {
-	state       sessionState
-	searchInput textinput.Model
-	spinner     spinner.Model
-	list        list.Model
-	resultCount int
-	err         error
-	width       int
-	height      int
-}
-
-type MovHubResponse struct {
-	Status string `json:"status"`
-	Result struct {
-		Count int    `json:"count"`
-		HTML  string `json:"html"`
-	} `json:"result"`
-}
-
-// -- Init ---------------------------------------------------------------------
-func initialModel() model {
-	ti := textinput.New()
-	ti.Placeholder = "search title..."
-	ti.Focus()
-	ti.CharLimit = 100
-	ti.Width = 44
-	ti.PromptStyle = lipgloss.NewStyle().Foreground(clrYellow).Bold(true)
-	ti.TextStyle = lipgloss.NewStyle().Foreground(clrWhite)
-	ti.Cursor.Style = lipgloss.NewStyle().Foreground(clrYellow)
-
-	sp := spinner.New()
-	sp.Spinner = spinner.Line
-	sp.Style = lipgloss.NewStyle().Foreground(clrYellow)
-
-	l := list.New([]list.Item{}, delegate{}, 0, 0)
-	l.SetShowTitle(false)
-	l.SetShowStatusBar(false)
-	l.SetShowHelp(false)
-	l.SetFilteringEnabled(true)
-	l.InfiniteScrolling = true
-
-	return model{
-		state:       stateSearch,
-		searchInput: ti,
-		spinner:     sp,
-		list:        l,
-	}
-}
-
-func (m model) Init() tea.Cmd {
-	return textinput.Blink
-}
-
-// -- Update -------------------------------------------------------------------
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
-			return m, tea.Quit
-		case "q":
-			if m.state != stateResults || !m.list.SettingFilter() {
-				return m, tea.Quit
-			}
-		case "esc":
-			if m.state == stateResults || m.state == stateError {
-				m.state = stateSearch
-				m.searchInput.Focus()
-				return m, nil
-			}
-		case "enter":
-			if m.state == stateSearch && m.searchInput.Value() != "" {
-				m.state = stateLoading
-				query := m.searchInput.Value()
-				return m, tea.Batch(m.spinner.Tick, fetchMoviesCmd(query))
-			} else if m.state == stateResults {
-				i, ok := m.list.SelectedItem().(item)
-				if ok {
-					openBrowser("https://movhub.ws" + i.itemURL)
-					return m, tea.Quit
-				}
-			}
-		}
-
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		listWidth := msg.Width - 8
-		if listWidth > 84 {
-			listWidth = 84
-		}
-		m.list.SetSize(listWidth, msg.Height-7)
-
-	case []list.Item:
-		m.resultCount = len(msg)
-		m.list.SetItems(msg)
-		m.state = stateResults
-		return m, nil
-
-	case error:
-		m.err = msg
-		m.state = stateError
-		return m, nil
-	}
-
-	switch m.state {
-	case stateSearch:
-		m.searchInput, cmd = m.searchInput.Update(msg)
-	case stateLoading:
-		m.spinner, cmd = m.spinner.Update(msg)
-	case stateResults:
-		m.list, cmd = m.list.Update(msg)
-	}
-
-	return m, cmd
-}
-
-// -- Views --------------------------------------------------------------------
-func (m model) View() string {
-	if m.width == 0 {
-		return ""
-	}
-	switch m.state {
-	case stateSearch:
-		return m.viewSearch()
-	case stateLoading:
-		return m.viewLoading()
-	case stateResults:
-		return m.viewResults()
-	case stateError:
-		return m.viewError()
-	}
-	return ""
-}
-
-func (m model) viewSearch() string {
-	logo := logoStyle.Render("MOVCLI")
-	sub := subStyle.Render("stream anything from your terminal")
-	div := divStyle.Render(strings.Repeat("-", 50))
-	label := labelStyle.Render("SEARCH")
-	field := "  " + m.searchInput.View()
-
-	enter := keyStyle.Render("ENTER")
-	quit := keyStyle.Render("CTRL+C")
-	hint := hintStyle.Render(fmt.Sprintf("  %s search   %s quit", enter, quit))
-
-	inner := strings.Join([]string{logo, sub, "", div, "", label, field, "", hint}, "\n")
-	box := outerStyle.Render(inner)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
-}
-
-func (m model) viewLoading() string {
-	line := loadStyle.Render(fmt.Sprintf("  %s  searching for \"%s\"", m.spinner.View(), m.searchInput.Value()))
-	box := outerStyle.Render("\n" + line + "\n")
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
-}
-
-func (m model) viewResults() string {
-	header := listHeaderStyle.Render("RESULTS")
-	count := countStyle.Render(fmt.Sprintf("  %d results for \"%s\"", m.resultCount, m.searchInput.Value()))
-	headerRow := lipgloss.JoinHorizontal(lipgloss.Center, header, count)
-
-	div := divStyle.Render(strings.Repeat("-", m.list.Width()))
-
-	updown := keyStyle.Render("UP/DOWN")
-	enter := keyStyle.Render("ENTER")
-	esc := keyStyle.Render("ESC")
-	slash := keyStyle.Render("/")
-	hints := hintStyle.Render(fmt.Sprintf("  %s navigate   %s open   %s back   %s filter", updown, enter, esc, slash))
-
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top,
-		lipgloss.JoinVertical(lipgloss.Left,
-			headerRow,
-			div,
-			m.list.View(),
-			div,
-			hints,
-		),
-	)
-}
-
-func (m model) viewError() string {
-	label := labelStyle.Render("ERROR")
-	msg := lipgloss.NewStyle().Foreground(clrWhite).Render(m.err.Error())
-	hint := hintStyle.Render("press ESC to go back")
-	box := errStyle.Render(strings.Join([]string{label, "", msg, "", hint}, "\n"))
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
-}
-
-// -- HTTP fetch ---------------------------------------------------------------
-func fetchMoviesCmd(query string) tea.Cmd {
-	return func() tea.Msg {
-		targetURL := fmt.Sprintf("https://movhub.ws/ajax/film/search?keyword=%s", url.QueryEscape(query))
-		req, err := http.NewRequest("GET", targetURL, nil)
-		if err != nil {
-			return err
-		}
-		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
-		req.Header.Set("X-Requested-With", "XMLHttpRequest")
-		req.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
-
-		client := &http.Client{Timeout: 10 * time.Second}
-		resp, err := client.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-
-		var apiResp MovHubResponse
-		if err := json.Unmarshal(body, &apiResp); err != nil {
-			return err
-		}
-
-		re := regexp.MustCompile(`<a class="item" href="([^"]+)">.*?<span>([^<]+)</span>.*?<span>([^<]+)</span>.*?<span>([^<]+)</span>.*?<div class="title">([^<]+)</div>`)
-		matches := re.FindAllStringSubmatch(apiResp.Result.HTML, -1)
-
-		var items []list.Item
-		for _, m := range matches {
-			if len(m) == 6 {
-				desc := fmt.Sprintf("%s  %s  %s", m[2], m[3], m[4])
-				items = append(items, item{title: m[5], desc: desc, itemURL: m[1]})
-			}
-		}
-
-		if len(items) == 0 {
-			return fmt.Errorf("no results for %q", query)
-		}
-		return items
-	}
-}
-
-// -- Utilities ----------------------------------------------------------------
-func openBrowser(u string) {
-	var err error
-	switch runtime.GOOS {
-	case "linux":
-		if err = exec.Command("xdg-open", u).Start(); err != nil {
-			err = exec.Command("am", "start", "--user", "0",
-				"-a", "android.intent.action.VIEW",
-				"-d", u).Start()
-		}
-	case "windows":
-		err = exec.Command("rundll32", "url.dll,FileProtocolHandler", u).Start()
-	case "darwin":
-		err = exec.Command("open", u).Start()
-	default:
-		err = fmt.Errorf("unsupported platform")
-	}
-	if err != nil {
-		fmt.Printf("Open in browser: %s\n", u)
-	}
-}
-
-func selfCleanup() {
-	exe, err := os.Executable()
-	if err != nil {
-		return
-	}
-	go func() {
-		time.Sleep(500 * time.Millisecond)
-		os.Remove(exe)
-	}()
-}
-
-func main() {
-	defer selfCleanup()
-
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
-	}
-}
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/JMJAJ/movcli/config"
+	"github.com/JMJAJ/movcli/provider"
+	"github.com/JMJAJ/movcli/scraper"
+	"github.com/JMJAJ/movcli/useragent"
+)
+
+// -- Palette ------------------------------------------------------------------
+var (
+	clrYellow = lipgloss.Color("#F5E642")
+	clrWhite  = lipgloss.Color("#EEEEEE")
+	clrGray   = lipgloss.Color("#888888")
+	clrDark   = lipgloss.Color("#444444")
+	clrBlack  = lipgloss.Color("#111111")
+)
+
+// -- Styles -------------------------------------------------------------------
+var (
+	outerStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(clrWhite).
+			Padding(1, 3).
+			Width(58)
+
+	logoStyle = lipgloss.NewStyle().
+			Foreground(clrYellow).
+			Bold(true)
+
+	subStyle = lipgloss.NewStyle().
+			Foreground(clrGray)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(clrYellow).
+			Bold(true)
+
+	divStyle = lipgloss.NewStyle().
+			Foreground(clrDark)
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(clrGray)
+
+	keyStyle = lipgloss.NewStyle().
+			Foreground(clrBlack).
+			Background(clrYellow).
+			Bold(true).
+			Padding(0, 1)
+
+	loadStyle = lipgloss.NewStyle().
+			Foreground(clrWhite).
+			Bold(true)
+
+	errStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(clrYellow).
+			Foreground(clrWhite).
+			Padding(1, 3)
+
+	selectedTitleStyle = lipgloss.NewStyle().
+				Foreground(clrYellow).
+				Bold(true)
+
+	normalTitleStyle = lipgloss.NewStyle().
+				Foreground(clrWhite)
+
+	selectedDescStyle = lipgloss.NewStyle().
+				Foreground(clrGray)
+
+	normalDescStyle = lipgloss.NewStyle().
+			Foreground(clrDark)
+
+	listHeaderStyle = lipgloss.NewStyle().
+			Foreground(clrBlack).
+			Background(clrYellow).
+			Bold(true).
+			Padding(0, 2)
+
+	countStyle = lipgloss.NewStyle().
+			Foreground(clrGray)
+
+	tabStyle = lipgloss.NewStyle().
+			Foreground(clrGray).
+			Padding(0, 2)
+
+	activeTabStyle = lipgloss.NewStyle().
+			Foreground(clrBlack).
+			Background(clrYellow).
+			Bold(true).
+			Padding(0, 2)
+)
+
+// -- Session state ------------------------------------------------------------
+type sessionState int
+
+const (
+	stateSearch sessionState = iota
+	stateLoading
+	stateResults
+	stateDetail
+	stateError
+)
+
+// overlayState is a full-screen view layered on top of whichever tab is
+// active, reachable from the search view and dismissed with esc.
+type overlayState int
+
+const (
+	overlayNone overlayState = iota
+	overlayHistory
+	overlayWatchlist
+)
+
+// -- Custom delegate ----------------------------------------------------------
+type delegate struct{}
+
+func (d delegate) Height() int                             { return 2 }
+func (d delegate) Spacing() int                            { return 1 }
+func (d delegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d delegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(provider.Item)
+	if !ok {
+		return
+	}
+	sel := index == m.Index()
+	prefix := "  "
+	titleS := normalTitleStyle.Render(i.Title())
+	descS := normalDescStyle.Render(i.Description())
+	if sel {
+		prefix = selectedTitleStyle.Render("> ")
+		titleS = selectedTitleStyle.Render(i.Title())
+		descS = selectedDescStyle.Render(i.Description())
+	}
+	fmt.Fprintf(w, "%s%s\n  %s", prefix, titleS, descS)
+}
+
+// -- Tabs ----------------------------------------------------------------------
+// tab bundles everything that must survive switching away and back: its own
+// provider, search input, results, and session state.
+type tab struct {
+	provider    provider.Provider
+	state       sessionState
+	searchInput textinput.Model
+	list        list.Model
+	resultCount int
+	err         error
+
+	selected     provider.Item
+	viewport     viewport.Model
+	detail       provider.Detail
+	detailLoaded bool
+	posterArt    string
+
+	// retry re-issues whatever action last landed this tab in stateError
+	// and reports the state that action runs in (stateLoading for a
+	// search, stateDetail for a detail fetch), set alongside err so the
+	// "r" hotkey doesn't need to know what kind of action failed.
+	retry func() (sessionState, tea.Cmd)
+}
+
+func newTab(p provider.Provider) tab {
+	ti := textinput.New()
+	ti.Placeholder = "search title..."
+	ti.CharLimit = 100
+	ti.Width = 44
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(clrYellow).Bold(true)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(clrWhite)
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(clrYellow)
+
+	l := list.New([]list.Item{}, delegate{}, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(true)
+	l.InfiniteScrolling = true
+
+	return tab{
+		provider:    p,
+		state:       stateSearch,
+		searchInput: ti,
+		list:        l,
+		viewport:    viewport.New(0, 0),
+	}
+}
+
+// -- App model ----------------------------------------------------------------
+type model struct {
+	tabs      []tab
+	activeTab int
+	spinner   spinner.Model
+	width     int
+	height    int
+
+	cfg   *config.Config
+	cfgCh <-chan *config.Config
+
+	history     *config.History
+	historyList list.Model
+
+	watchlistStore *config.Watchlist
+	watchlistTab   *provider.Watchlist
+	watchlistList  list.Model
+
+	overlay overlayState
+}
+
+// -- Messages -------------------------------------------------------------------
+// Search results arrive asynchronously, so every async message carries the
+// index of the tab that triggered it; by the time it arrives the user may
+// have switched tabs, and we must not clobber a different tab's state.
+type searchResultMsg struct {
+	tab   int
+	items []list.Item
+}
+
+type searchErrMsg struct {
+	tab int
+	err error
+}
+
+// configUpdatedMsg carries a config.json reload triggered by an external
+// edit while the TUI is running.
+type configUpdatedMsg struct {
+	cfg *config.Config
+}
+
+// -- Init ---------------------------------------------------------------------
+func initialModel() model {
+	useragent.Prime()
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{APIKeys: map[string]string{}}
+	}
+	hist, err := config.LoadHistory()
+	if err != nil {
+		hist = &config.History{}
+	}
+	wl, err := config.LoadWatchlist()
+	if err != nil {
+		wl = &config.Watchlist{}
+	}
+
+	movhub := provider.NewMovHub()
+	providers := []provider.Provider{movhub}
+	tabs := []tab{newTab(movhub)}
+	if tmdb, err := provider.NewTMDB(cfg.APIKeys["tmdb"]); err == nil {
+		providers = append(providers, tmdb)
+		tabs = append(tabs, newTab(tmdb))
+	}
+
+	watchlistTab := provider.NewWatchlist(providers...)
+	watchlistTab.Load(watchlistEntriesToItems(wl.Entries))
+	tabs = append(tabs, newTab(watchlistTab))
+
+	active := preferredTabIndex(tabs, cfg.PreferredProvider)
+	tabs[active].searchInput.Focus()
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Line
+	sp.Style = lipgloss.NewStyle().Foreground(clrYellow)
+
+	overlayList := func() list.Model {
+		l := list.New([]list.Item{}, delegate{}, 0, 0)
+		l.SetShowTitle(false)
+		l.SetShowStatusBar(false)
+		l.SetShowHelp(false)
+		return l
+	}
+
+	cfgCh, err := config.Watch()
+	if err != nil {
+		cfgCh = nil
+	}
+
+	return model{
+		tabs:      tabs,
+		activeTab: active,
+		spinner:   sp,
+
+		cfg:   cfg,
+		cfgCh: cfgCh,
+
+		history:     hist,
+		historyList: overlayList(),
+
+		watchlistStore: wl,
+		watchlistTab:   watchlistTab,
+		watchlistList:  overlayList(),
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	if m.cfgCh == nil {
+		return textinput.Blink
+	}
+	return tea.Batch(textinput.Blink, waitForConfigUpdate(m.cfgCh))
+}
+
+// waitForConfigUpdate blocks on the config-reload channel for the next
+// reload, following bubbletea's standard pattern for bridging an external
+// event source into the Update loop; Update re-arms it after each
+// configUpdatedMsg.
+func waitForConfigUpdate(ch <-chan *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		cfg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return configUpdatedMsg{cfg: cfg}
+	}
+}
+
+func watchlistEntriesToItems(entries []config.WatchlistEntry) []provider.Item {
+	items := make([]provider.Item, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, provider.Item{TitleText: e.Title, DescText: e.Desc, URL: e.URL, Provider: e.Provider})
+	}
+	return items
+}
+
+// preferredTabIndex returns the index of the tab whose provider matches
+// cfg.PreferredProvider, or 0 if it's unset or none match.
+func preferredTabIndex(tabs []tab, preferred string) int {
+	if preferred == "" {
+		return 0
+	}
+	for i, t := range tabs {
+		if strings.EqualFold(t.provider.Name(), preferred) {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *model) current() *tab {
+	return &m.tabs[m.activeTab]
+}
+
+// nextTab moves the active tab by delta, wrapping around, and re-focuses the
+// destination tab's search input when it's idle.
+func (m *model) nextTab(delta int) {
+	m.tabs[m.activeTab].searchInput.Blur()
+	n := len(m.tabs)
+	m.activeTab = ((m.activeTab+delta)%n + n) % n
+	t := m.current()
+	if t.state == stateSearch {
+		t.searchInput.Focus()
+	}
+}
+
+// hasProvider reports whether any tab is already backed by a provider
+// named name.
+func (m *model) hasProvider(name string) bool {
+	for _, t := range m.tabs {
+		if t.provider.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addProviderTab appends a tab for p, ahead of the watchlist tab (kept
+// last by convention), registers p with the watchlist so items bookmarked
+// from it can be resolved, and sizes the new tab to the current window.
+func (m *model) addProviderTab(p provider.Provider) {
+	insertAt := len(m.tabs) - 1
+	t := newTab(p)
+	m.tabs = append(m.tabs, tab{})
+	copy(m.tabs[insertAt+1:], m.tabs[insertAt:])
+	m.tabs[insertAt] = t
+	m.watchlistTab.AddProvider(p)
+	if m.width > 0 {
+		m.resizeTab(insertAt)
+	}
+}
+
+// listSize returns the list/viewport dimensions derived from the current
+// window size, shared by every tab and the history/watchlist overlays.
+func (m *model) listSize() (width, height int) {
+	width = m.width - 8
+	if width > 84 {
+		width = 84
+	}
+	return width, m.height - 9
+}
+
+// resizeTab applies the current window size to tab i's list and viewport.
+func (m *model) resizeTab(i int) {
+	width, height := m.listSize()
+	m.tabs[i].list.SetSize(width, height)
+	m.tabs[i].viewport.Width = width
+	m.tabs[i].viewport.Height = height
+}
+
+// -- Update -------------------------------------------------------------------
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "ctrl+h":
+			if m.overlay == overlayHistory {
+				m.overlay = overlayNone
+			} else {
+				m.historyList.SetItems(historyEntriesToListItems(m.history.Entries))
+				m.overlay = overlayHistory
+			}
+			return m, nil
+		case "ctrl+w":
+			if m.overlay == overlayWatchlist {
+				m.overlay = overlayNone
+			} else {
+				m.watchlistList.SetItems(itemsToListItems(m.watchlistTab.Items()))
+				m.overlay = overlayWatchlist
+			}
+			return m, nil
+		case "tab":
+			if m.overlay == overlayNone {
+				m.nextTab(1)
+			}
+			return m, nil
+		case "shift+tab":
+			if m.overlay == overlayNone {
+				m.nextTab(-1)
+			}
+			return m, nil
+		case "q":
+			t := m.current()
+			if m.overlay == overlayNone && (t.state != stateResults || !t.list.SettingFilter()) {
+				return m, tea.Quit
+			}
+		case "esc":
+			if m.overlay != overlayNone {
+				m.overlay = overlayNone
+				return m, nil
+			}
+			t := m.current()
+			if t.state == stateDetail {
+				t.state = stateResults
+				return m, nil
+			}
+			if t.state == stateResults || t.state == stateError {
+				t.state = stateSearch
+				t.searchInput.Focus()
+				return m, nil
+			}
+		case "p":
+			t := m.current()
+			if t.state == stateDetail {
+				return m, playCmd(m.cfg, t.provider, t.selected)
+			}
+		case "o":
+			t := m.current()
+			if t.state == stateDetail {
+				if u, err := t.provider.Resolve(t.selected); err == nil {
+					openBrowser(u)
+				}
+				return m, tea.Quit
+			}
+		case "r":
+			t := m.current()
+			if t.state == stateError && canRetry(t.err) && t.retry != nil {
+				var retryCmd tea.Cmd
+				t.state, retryCmd = t.retry()
+				return m, retryCmd
+			}
+		case "s":
+			t := m.current()
+			if t.state == stateError && canReport(t.err) {
+				var perr *scraper.ParseError
+				if errors.As(t.err, &perr) {
+					if path, dumpErr := dumpFailedHTML(perr.HTML); dumpErr == nil {
+						t.err = fmt.Errorf("%w (saved to %s)", t.err, path)
+					}
+				}
+				return m, nil
+			}
+		case "w":
+			t := m.current()
+			if t.state == stateDetail {
+				m.watchlistTab.Add(t.selected)
+				m.watchlistStore.Add(config.WatchlistEntry{
+					Provider: t.provider.Name(),
+					Title:    t.selected.TitleText,
+					Desc:     t.selected.DescText,
+					URL:      t.selected.URL,
+				})
+				m.watchlistStore.Save()
+				return m, nil
+			}
+		case "enter":
+			if m.overlay == overlayHistory {
+				if i, ok := m.historyList.SelectedItem().(provider.Item); ok {
+					t := m.current()
+					t.searchInput.SetValue(i.TitleText)
+					t.state = stateLoading
+					m.overlay = overlayNone
+					idx := m.activeTab
+					return m, tea.Batch(m.spinner.Tick, fetchCmd(t.provider, idx, i.TitleText))
+				}
+				return m, nil
+			}
+			if m.overlay == overlayWatchlist {
+				if i, ok := m.watchlistList.SelectedItem().(provider.Item); ok {
+					m.overlay = overlayNone
+					if u, err := m.watchlistTab.Resolve(i); err == nil {
+						openBrowser(u)
+					}
+					return m, tea.Quit
+				}
+				return m, nil
+			}
+			t := m.current()
+			if t.state == stateSearch && t.searchInput.Value() != "" {
+				t.state = stateLoading
+				query := t.searchInput.Value()
+				idx := m.activeTab
+				m.history.Add(query)
+				m.history.Save()
+				return m, tea.Batch(m.spinner.Tick, fetchCmd(t.provider, idx, query))
+			} else if t.state == stateResults {
+				i, ok := t.list.SelectedItem().(provider.Item)
+				if ok {
+					t.selected = i
+					t.detailLoaded = false
+					t.state = stateDetail
+					idx := m.activeTab
+					return m, fetchDetailCmd(t.provider, idx, i)
+				}
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		for i := range m.tabs {
+			m.resizeTab(i)
+		}
+		listWidth, listHeight := m.listSize()
+		m.historyList.SetSize(listWidth, listHeight)
+		m.watchlistList.SetSize(listWidth, listHeight)
+
+	case configUpdatedMsg:
+		prevTMDBKey := m.cfg.APIKeys["tmdb"]
+		m.cfg = msg.cfg
+		if tmdbKey := m.cfg.APIKeys["tmdb"]; tmdbKey != "" && tmdbKey != prevTMDBKey && !m.hasProvider("TMDB") {
+			if tmdb, err := provider.NewTMDB(tmdbKey); err == nil {
+				m.addProviderTab(tmdb)
+			}
+		}
+		return m, waitForConfigUpdate(m.cfgCh)
+
+	case detailResultMsg:
+		t := &m.tabs[msg.tab]
+		t.detail = msg.detail
+		t.posterArt = msg.poster
+		t.detailLoaded = true
+		t.viewport.SetContent(buildDetailContent(t.detail, t.posterArt))
+		t.viewport.GotoTop()
+		return m, nil
+
+	case detailErrMsg:
+		t := &m.tabs[msg.tab]
+		t.err = msg.err
+		t.state = stateError
+		prov, idx, it := t.provider, msg.tab, t.selected
+		t.retry = func() (sessionState, tea.Cmd) {
+			return stateDetail, fetchDetailCmd(prov, idx, it)
+		}
+		return m, nil
+
+	case playErrMsg:
+		t := m.current()
+		t.err = msg.err
+		t.state = stateError
+		return m, nil
+
+	case searchResultMsg:
+		t := &m.tabs[msg.tab]
+		t.resultCount = len(msg.items)
+		t.list.SetItems(msg.items)
+		t.state = stateResults
+		return m, nil
+
+	case searchErrMsg:
+		t := &m.tabs[msg.tab]
+		t.err = msg.err
+		t.state = stateError
+		prov, idx, query := t.provider, msg.tab, t.searchInput.Value()
+		sp := m.spinner
+		t.retry = func() (sessionState, tea.Cmd) {
+			return stateLoading, tea.Batch(sp.Tick, fetchCmd(prov, idx, query))
+		}
+		return m, nil
+	}
+
+	switch m.overlay {
+	case overlayHistory:
+		m.historyList, cmd = m.historyList.Update(msg)
+		return m, cmd
+	case overlayWatchlist:
+		m.watchlistList, cmd = m.watchlistList.Update(msg)
+		return m, cmd
+	}
+
+	t := m.current()
+	switch t.state {
+	case stateSearch:
+		t.searchInput, cmd = t.searchInput.Update(msg)
+	case stateLoading:
+		m.spinner, cmd = m.spinner.Update(msg)
+	case stateResults:
+		t.list, cmd = t.list.Update(msg)
+	case stateDetail:
+		if t.detailLoaded {
+			t.viewport, cmd = t.viewport.Update(msg)
+		} else {
+			m.spinner, cmd = m.spinner.Update(msg)
+		}
+	}
+
+	return m, cmd
+}
+
+func historyEntriesToListItems(entries []config.HistoryEntry) []list.Item {
+	items := make([]list.Item, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, provider.Item{
+			TitleText: e.Query,
+			DescText:  e.Time.Format("2006-01-02 15:04"),
+		})
+	}
+	return items
+}
+
+func itemsToListItems(items []provider.Item) []list.Item {
+	out := make([]list.Item, 0, len(items))
+	for _, i := range items {
+		out = append(out, i)
+	}
+	return out
+}
+
+// -- Views --------------------------------------------------------------------
+func (m model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	switch m.overlay {
+	case overlayHistory:
+		return m.viewOverlayList("HISTORY", m.historyList)
+	case overlayWatchlist:
+		return m.viewOverlayList("WATCHLIST", m.watchlistList)
+	}
+	t := m.tabs[m.activeTab]
+	switch t.state {
+	case stateSearch:
+		return m.viewSearch()
+	case stateLoading:
+		return m.viewLoading()
+	case stateResults:
+		return m.viewResults()
+	case stateDetail:
+		return m.viewDetail()
+	case stateError:
+		return m.viewError()
+	}
+	return ""
+}
+
+func (m model) viewTabBar() string {
+	rendered := make([]string, len(m.tabs))
+	for i, t := range m.tabs {
+		if i == m.activeTab {
+			rendered[i] = activeTabStyle.Render(t.provider.Name())
+		} else {
+			rendered[i] = tabStyle.Render(t.provider.Name())
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (m model) viewSearch() string {
+	t := m.tabs[m.activeTab]
+	logo := logoStyle.Render("MOVCLI")
+	sub := subStyle.Render("stream anything from your terminal")
+	tabs := m.viewTabBar()
+	div := divStyle.Render(strings.Repeat("-", 50))
+	label := labelStyle.Render("SEARCH")
+	field := "  " + t.searchInput.View()
+
+	enter := keyStyle.Render("ENTER")
+	swap := keyStyle.Render("TAB")
+	hist := keyStyle.Render("CTRL+H")
+	watch := keyStyle.Render("CTRL+W")
+	quit := keyStyle.Render("CTRL+C")
+	hint := hintStyle.Render(fmt.Sprintf("  %s search   %s switch   %s history   %s watchlist   %s quit", enter, swap, hist, watch, quit))
+
+	inner := strings.Join([]string{logo, sub, "", tabs, "", div, "", label, field, "", hint}, "\n")
+	box := outerStyle.Render(inner)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m model) viewLoading() string {
+	t := m.tabs[m.activeTab]
+	line := loadStyle.Render(fmt.Sprintf("  %s  searching for \"%s\"", m.spinner.View(), t.searchInput.Value()))
+	box := outerStyle.Render("\n" + line + "\n")
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m model) viewResults() string {
+	t := m.tabs[m.activeTab]
+	tabs := m.viewTabBar()
+	header := listHeaderStyle.Render("RESULTS")
+	count := countStyle.Render(fmt.Sprintf("  %d results for \"%s\"", t.resultCount, t.searchInput.Value()))
+	headerRow := lipgloss.JoinHorizontal(lipgloss.Center, header, count)
+
+	div := divStyle.Render(strings.Repeat("-", t.list.Width()))
+
+	updown := keyStyle.Render("UP/DOWN")
+	enter := keyStyle.Render("ENTER")
+	esc := keyStyle.Render("ESC")
+	slash := keyStyle.Render("/")
+	swap := keyStyle.Render("TAB")
+	hints := hintStyle.Render(fmt.Sprintf("  %s navigate   %s details   %s back   %s filter   %s switch", updown, enter, esc, slash, swap))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left,
+			tabs,
+			headerRow,
+			div,
+			t.list.View(),
+			div,
+			hints,
+		),
+	)
+}
+
+func (m model) viewOverlayList(title string, l list.Model) string {
+	header := listHeaderStyle.Render(title)
+	div := divStyle.Render(strings.Repeat("-", l.Width()))
+
+	updown := keyStyle.Render("UP/DOWN")
+	enter := keyStyle.Render("ENTER")
+	esc := keyStyle.Render("ESC")
+	var action string
+	if title == "HISTORY" {
+		action = "re-search"
+	} else {
+		action = "open"
+	}
+	hints := hintStyle.Render(fmt.Sprintf("  %s navigate   %s %s   %s back", updown, enter, action, esc))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left,
+			header,
+			div,
+			l.View(),
+			div,
+			hints,
+		),
+	)
+}
+
+func (m model) viewError() string {
+	t := m.tabs[m.activeTab]
+	label := labelStyle.Render(errorLabel(t.err))
+	msg := lipgloss.NewStyle().Foreground(clrWhite).Render(t.err.Error())
+
+	esc := keyStyle.Render("ESC")
+	hintParts := []string{fmt.Sprintf("%s back", esc)}
+	if canRetry(t.err) {
+		hintParts = append(hintParts, fmt.Sprintf("%s retry", keyStyle.Render("R")))
+	}
+	if canReport(t.err) {
+		hintParts = append(hintParts, fmt.Sprintf("%s save page for a bug report", keyStyle.Render("S")))
+	}
+	hint := hintStyle.Render("  " + strings.Join(hintParts, "   "))
+
+	box := errStyle.Render(strings.Join([]string{label, "", msg, "", hint}, "\n"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// -- Search ---------------------------------------------------------------------
+func fetchCmd(p provider.Provider, tabIdx int, query string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := p.Search(query)
+		if err != nil {
+			return searchErrMsg{tab: tabIdx, err: err}
+		}
+		return searchResultMsg{tab: tabIdx, items: items}
+	}
+}
+
+// -- Utilities ----------------------------------------------------------------
+func openBrowser(u string) {
+	var err error
+	switch runtime.GOOS {
+	case "linux":
+		if err = exec.Command("xdg-open", u).Start(); err != nil {
+			err = exec.Command("am", "start", "--user", "0",
+				"-a", "android.intent.action.VIEW",
+				"-d", u).Start()
+		}
+	case "windows":
+		err = exec.Command("rundll32", "url.dll,FileProtocolHandler", u).Start()
+	case "darwin":
+		err = exec.Command("open", u).Start()
+	default:
+		err = fmt.Errorf("unsupported platform")
+	}
+	if err != nil {
+		fmt.Printf("Open in browser: %s\n", u)
+	}
+}
+
+func selfCleanup() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		os.Remove(exe)
+	}()
+}
+
+func main() {
+	defer selfCleanup()
+
+	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JMJAJ/movcli/config"
+	"github.com/JMJAJ/movcli/extractor"
+	"github.com/JMJAJ/movcli/player"
+	"github.com/JMJAJ/movcli/provider"
+)
+
+var (
+	errNoStreamer  = errors.New("provider has no stream source")
+	errNoExtractor = errors.New("no extractor for this embed host")
+)
+
+// playErrMsg reports that playback couldn't even fall back to opening a
+// browser (e.g. Resolve itself failed).
+type playErrMsg struct {
+	err error
+}
+
+// playCmd resolves it to a direct stream URL (when the provider supports
+// it) and hands it to a local player; if extraction or playback isn't
+// possible it falls back to opening the resolved page in the browser, the
+// same way openBrowser always has.
+func playCmd(cfg *config.Config, p provider.Provider, it provider.Item) tea.Cmd {
+	return func() tea.Msg {
+		if streamURL, headers, err := extractStream(p, it); err == nil {
+			if pl, err := player.Default(cfg.PlayerCommand); err == nil {
+				if err := pl.Play(streamURL, it.TitleText, headers); err == nil {
+					return tea.Quit()
+				}
+			}
+		}
+
+		u, err := p.Resolve(it)
+		if err != nil {
+			return playErrMsg{err: err}
+		}
+		openBrowser(u)
+		return tea.Quit()
+	}
+}
+
+// extractStream asks the provider for its embed page, if it has one, and
+// runs it through whichever extractor in the extractor package claims it.
+func extractStream(p provider.Provider, it provider.Item) (string, map[string]string, error) {
+	streamer, ok := p.(provider.Streamer)
+	if !ok {
+		return "", nil, errNoStreamer
+	}
+	embedURL, err := streamer.StreamSource(it)
+	if err != nil {
+		return "", nil, err
+	}
+	ex, ok := extractor.For(embedURL)
+	if !ok {
+		return "", nil, errNoExtractor
+	}
+	return ex.Extract(embedURL)
+}
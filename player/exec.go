@@ -0,0 +1,61 @@
+package player
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// execPlayer shells out to a CLI media player binary. Each supported binary
+// gets its own argument format since mpv, vlc, and iina each spell
+// "custom title" and "extra HTTP header" differently.
+type execPlayer struct {
+	bin string
+}
+
+func newExecPlayer(bin string) *execPlayer {
+	return &execPlayer{bin: bin}
+}
+
+func (p *execPlayer) Play(streamURL, title string, headers map[string]string) error {
+	args := p.buildArgs(streamURL, title, headers)
+	return exec.Command(p.bin, args...).Start()
+}
+
+func (p *execPlayer) buildArgs(streamURL, title string, headers map[string]string) []string {
+	switch strings.TrimSuffix(filepath.Base(p.bin), filepath.Ext(p.bin)) {
+	case "vlc":
+		// Only the Referer header is passed to vlc; other headers are dropped.
+		args := []string{fmt.Sprintf("--meta-title=%s", title)}
+		if ref, ok := headers["Referer"]; ok {
+			args = append(args, fmt.Sprintf("--http-referrer=%s", ref))
+		}
+		return append(args, streamURL)
+	case "iina":
+		args := []string{fmt.Sprintf("--mpv-force-media-title=%s", title)}
+		if h := headerFieldsArg(headers); h != "" {
+			args = append(args, fmt.Sprintf("--mpv-http-header-fields=%s", h))
+		}
+		return append(args, streamURL)
+	default: // mpv
+		args := []string{fmt.Sprintf("--force-media-title=%s", title)}
+		if h := headerFieldsArg(headers); h != "" {
+			args = append(args, fmt.Sprintf("--http-header-fields=%s", h))
+		}
+		return append(args, streamURL)
+	}
+}
+
+// headerFieldsArg renders headers the way mpv/iina expect them:
+// --http-header-fields="Referer: x,User-Agent: y".
+func headerFieldsArg(headers map[string]string) string {
+	if len(headers) == 0 {
+		return ""
+	}
+	var fields []string
+	for k, v := range headers {
+		fields = append(fields, fmt.Sprintf("%s: %s", k, v))
+	}
+	return strings.Join(fields, ",")
+}
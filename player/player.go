@@ -0,0 +1,39 @@
+// Package player launches an external media player pointed at a direct
+// stream URL, mirroring the platform-dispatch style of openBrowser in the
+// main package but for mpv/vlc/iina instead of the OS's "open" command.
+package player
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Player starts a stream playing in an external application.
+type Player interface {
+	// Play launches title from streamURL. headers (e.g. Referer) are
+	// forwarded to the player so hosts that check them don't reject the
+	// request.
+	Play(streamURL, title string, headers map[string]string) error
+}
+
+// Default picks a Player to use: preferredCmd (from config.json) if it's on
+// PATH, else the first of mpv, vlc, and (on macOS) iina that's available.
+func Default(preferredCmd string) (Player, error) {
+	if preferredCmd != "" {
+		if _, err := exec.LookPath(preferredCmd); err == nil {
+			return newExecPlayer(preferredCmd), nil
+		}
+	}
+
+	candidates := []string{"mpv", "vlc"}
+	if runtime.GOOS == "darwin" {
+		candidates = append(candidates, "iina")
+	}
+	for _, bin := range candidates {
+		if _, err := exec.LookPath(bin); err == nil {
+			return newExecPlayer(bin), nil
+		}
+	}
+	return nil, fmt.Errorf("player: none of %v found on PATH", candidates)
+}
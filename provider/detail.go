@@ -0,0 +1,20 @@
+package provider
+
+import "github.com/charmbracelet/bubbles/list"
+
+// Detail is the rich metadata shown in the in-app detail view.
+type Detail struct {
+	Title     string
+	Year      string
+	Synopsis  string
+	Genres    []string
+	Cast      []string
+	PosterURL string
+}
+
+// Detailer is an optional capability: providers that can fetch a rich
+// detail page for an item implement it, and the UI type-asserts for it
+// before offering the detail view.
+type Detailer interface {
+	FetchDetail(it list.Item) (Detail, error)
+}
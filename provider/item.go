@@ -0,0 +1,18 @@
+package provider
+
+// Item is the shared list.Item implementation used by every provider so the
+// UI's delegate doesn't need to know which backend produced a result.
+type Item struct {
+	TitleText string
+	DescText  string
+	URL       string
+
+	// Provider is the Name() of whichever Provider produced this item,
+	// so a Watchlist holding items from several backends knows which one
+	// to route Resolve/FetchDetail/StreamSource back to.
+	Provider string
+}
+
+func (i Item) Title() string       { return i.TitleText }
+func (i Item) Description() string { return i.DescText }
+func (i Item) FilterValue() string { return i.TitleText }
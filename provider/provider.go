@@ -0,0 +1,17 @@
+// Package provider defines the pluggable search backend contract used by
+// the movcli TUI. Each tab in the UI is backed by one Provider, so adding a
+// new streaming source or metadata API is a matter of implementing this
+// interface rather than touching the UI layer.
+package provider
+
+import "github.com/charmbracelet/bubbles/list"
+
+// Provider is a source of searchable, resolvable content.
+type Provider interface {
+	// Name is the short label shown on the provider's tab.
+	Name() string
+	// Search runs query against the backend and returns matching items.
+	Search(query string) ([]list.Item, error)
+	// Resolve turns a selected item into a URL the caller can open or play.
+	Resolve(it list.Item) (string, error)
+}
@@ -0,0 +1,12 @@
+package provider
+
+import "github.com/charmbracelet/bubbles/list"
+
+// Streamer is an optional capability: providers backed by a page that
+// iframes a third-party embed implement it so playback can extract a
+// direct stream URL instead of only opening the page in a browser.
+type Streamer interface {
+	// StreamSource returns the embed URL (e.g. a vidsrc/streamtape/
+	// doodstream iframe) found on the item's resolved page.
+	StreamSource(it list.Item) (embedURL string, err error)
+}
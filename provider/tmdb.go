@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// TMDB searches The Movie Database's public API for metadata. Its API key
+// comes from config.json's api_keys.tmdb or, when that is unset, from the
+// TMDB_API_KEY environment variable.
+type TMDB struct {
+	apiKey string
+	client *http.Client
+}
+
+// NewTMDB returns a TMDB provider. apiKey, if non-empty, takes precedence
+// over the TMDB_API_KEY environment variable; an error is returned if
+// neither is set.
+func NewTMDB(apiKey string) (*TMDB, error) {
+	key := apiKey
+	if key == "" {
+		key = os.Getenv("TMDB_API_KEY")
+	}
+	if key == "" {
+		return nil, fmt.Errorf("tmdb: no API key (set TMDB_API_KEY or config.json api_keys.tmdb)")
+	}
+	return &TMDB{apiKey: key, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+func (p *TMDB) Name() string { return "TMDB" }
+
+type tmdbSearchResponse struct {
+	Results []struct {
+		ID          int    `json:"id"`
+		Title       string `json:"title"`
+		ReleaseDate string `json:"release_date"`
+		Overview    string `json:"overview"`
+	} `json:"results"`
+}
+
+func (p *TMDB) Search(query string) ([]list.Item, error) {
+	targetURL := fmt.Sprintf("https://api.themoviedb.org/3/search/movie?api_key=%s&query=%s",
+		url.QueryEscape(p.apiKey), url.QueryEscape(query))
+
+	resp, err := p.client.Get(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp tmdbSearchResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, err
+	}
+
+	var items []list.Item
+	for _, r := range apiResp.Results {
+		year := r.ReleaseDate
+		if len(year) >= 4 {
+			year = year[:4]
+		}
+		items = append(items, Item{
+			TitleText: r.Title,
+			DescText:  fmt.Sprintf("%s  %s", year, r.Overview),
+			URL:       fmt.Sprintf("https://www.themoviedb.org/movie/%d", r.ID),
+			Provider:  p.Name(),
+		})
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no results for %q", query)
+	}
+	return items, nil
+}
+
+func (p *TMDB) Resolve(it list.Item) (string, error) {
+	i, ok := it.(Item)
+	if !ok {
+		return "", fmt.Errorf("tmdb: unexpected item type %T", it)
+	}
+	return i.URL, nil
+}
+
+type tmdbDetailResponse struct {
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+	Overview    string `json:"overview"`
+	PosterPath  string `json:"poster_path"`
+	Genres      []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Credits struct {
+		Cast []struct {
+			Name string `json:"name"`
+		} `json:"cast"`
+	} `json:"credits"`
+}
+
+// FetchDetail looks up full metadata (genres, cast, poster) for the movie
+// ID embedded in the item's URL.
+func (p *TMDB) FetchDetail(it list.Item) (Detail, error) {
+	i, ok := it.(Item)
+	if !ok {
+		return Detail{}, fmt.Errorf("tmdb: unexpected item type %T", it)
+	}
+
+	id := i.URL[strings.LastIndex(i.URL, "/")+1:]
+	targetURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/%s?api_key=%s&append_to_response=credits",
+		url.QueryEscape(id), url.QueryEscape(p.apiKey))
+
+	resp, err := p.client.Get(targetURL)
+	if err != nil {
+		return Detail{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Detail{}, err
+	}
+
+	var apiResp tmdbDetailResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return Detail{}, err
+	}
+
+	year := apiResp.ReleaseDate
+	if len(year) >= 4 {
+		year = year[:4]
+	}
+
+	d := Detail{
+		Title:    apiResp.Title,
+		Year:     year,
+		Synopsis: apiResp.Overview,
+	}
+	if apiResp.PosterPath != "" {
+		d.PosterURL = "https://image.tmdb.org/t/p/w500" + apiResp.PosterPath
+	}
+	for _, g := range apiResp.Genres {
+		d.Genres = append(d.Genres, g.Name)
+	}
+	for n, c := range apiResp.Credits.Cast {
+		if n >= 8 {
+			break
+		}
+		d.Cast = append(d.Cast, c.Name)
+	}
+	return d, nil
+}
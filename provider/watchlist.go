@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Watchlist is a local tab of bookmarked items. It holds no network
+// connection of its own; resolving an item is delegated to whichever
+// provider originally produced it, keyed by the Item.Provider it was
+// bookmarked with.
+type Watchlist struct {
+	providers map[string]Provider
+	items     []Item
+}
+
+// NewWatchlist returns an empty watchlist that routes each item back to
+// whichever of providers produced it.
+func NewWatchlist(providers ...Provider) *Watchlist {
+	w := &Watchlist{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		w.providers[p.Name()] = p
+	}
+	return w
+}
+
+// AddProvider registers p as a resolution target, for a provider added
+// after the watchlist was created (e.g. a config reload that enables a
+// new backend).
+func (w *Watchlist) AddProvider(p Provider) {
+	w.providers[p.Name()] = p
+}
+
+// originOf returns the provider that produced it, by its stored
+// Item.Provider name.
+func (w *Watchlist) originOf(it list.Item) (Provider, error) {
+	i, ok := it.(Item)
+	if !ok {
+		return nil, fmt.Errorf("watchlist: unexpected item type %T", it)
+	}
+	p, ok := w.providers[i.Provider]
+	if !ok {
+		return nil, fmt.Errorf("watchlist: no provider named %q to resolve %q", i.Provider, i.TitleText)
+	}
+	return p, nil
+}
+
+func (w *Watchlist) Name() string { return "Watchlist" }
+
+// Add bookmarks it, ignoring duplicates by URL.
+func (w *Watchlist) Add(it Item) {
+	for _, existing := range w.items {
+		if existing.URL == it.URL {
+			return
+		}
+	}
+	w.items = append(w.items, it)
+}
+
+// Items returns the bookmarked items, in bookmark order.
+func (w *Watchlist) Items() []Item {
+	return w.items
+}
+
+// Load replaces the in-memory watchlist with items restored from disk.
+func (w *Watchlist) Load(items []Item) {
+	w.items = items
+}
+
+func (w *Watchlist) Search(query string) ([]list.Item, error) {
+	var items []list.Item
+	for _, it := range w.items {
+		if query == "" || strings.Contains(strings.ToLower(it.TitleText), strings.ToLower(query)) {
+			items = append(items, it)
+		}
+	}
+	if len(items) == 0 {
+		if len(w.items) == 0 {
+			return nil, fmt.Errorf("watchlist is empty")
+		}
+		return nil, fmt.Errorf("no watchlist items match %q", query)
+	}
+	return items, nil
+}
+
+func (w *Watchlist) Resolve(it list.Item) (string, error) {
+	p, err := w.originOf(it)
+	if err != nil {
+		return "", err
+	}
+	return p.Resolve(it)
+}
+
+// FetchDetail delegates to the item's origin provider, if it can fetch
+// detail pages.
+func (w *Watchlist) FetchDetail(it list.Item) (Detail, error) {
+	p, err := w.originOf(it)
+	if err != nil {
+		return Detail{}, err
+	}
+	d, ok := p.(Detailer)
+	if !ok {
+		return Detail{}, fmt.Errorf("watchlist: %s has no detail view", p.Name())
+	}
+	return d.FetchDetail(it)
+}
+
+// StreamSource delegates to the item's origin provider, if it can extract
+// a stream source.
+func (w *Watchlist) StreamSource(it list.Item) (string, error) {
+	p, err := w.originOf(it)
+	if err != nil {
+		return "", err
+	}
+	s, ok := p.(Streamer)
+	if !ok {
+		return "", fmt.Errorf("watchlist: %s has no stream source", p.Name())
+	}
+	return s.StreamSource(it)
+}
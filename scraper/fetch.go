@@ -0,0 +1,31 @@
+package scraper
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Fetch performs req and returns its body, translating transport failures
+// and blocked/overloaded responses into the typed errors callers branch on
+// instead of leaving every provider to guess at a response's meaning.
+func Fetch(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNetwork, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", ErrRateLimited
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return "", fmt.Errorf("%w: upstream returned %s", ErrNetwork, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNetwork, err)
+	}
+	return string(body), nil
+}
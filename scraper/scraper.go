@@ -0,0 +1,157 @@
+// Package scraper parses movhub's scraped HTML fragments with goquery and
+// classifies failures into typed errors, so a markup change becomes a
+// selector patch in one place instead of a regexp rewrite wherever the
+// provider package touched the markup directly.
+package scraper
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Sentinel errors callers can branch on with errors.Is, e.g. to offer a
+// retry for network trouble but not for a page that genuinely has nothing.
+var (
+	ErrNoResults   = errors.New("scraper: no results")
+	ErrParseFailed = errors.New("scraper: failed to parse page")
+	ErrRateLimited = errors.New("scraper: rate limited")
+	ErrNetwork     = errors.New("scraper: network error")
+)
+
+// ParseError wraps ErrParseFailed with the HTML that failed to parse, so a
+// caller can save it for a bug report instead of just logging the error
+// string.
+type ParseError struct {
+	HTML string
+}
+
+func (e *ParseError) Error() string { return ErrParseFailed.Error() }
+func (e *ParseError) Unwrap() error { return ErrParseFailed }
+
+// Result is one scraped search hit.
+type Result struct {
+	Title string
+	Desc  string
+	URL   string
+}
+
+// ParseSearchResults extracts search hits out of a movhub search-results
+// HTML fragment. Each hit is an `a.item` anchor carrying the detail URL,
+// a handful of descriptive `span`s, and a `div.title`.
+//
+// expectedCount is the result count movhub's own ajax endpoint reported
+// alongside the fragment. It's what lets us tell a genuine empty search
+// apart from our selectors going stale: if movhub says there are results
+// but none of them matched `a.item`, the markup changed underneath us.
+func ParseSearchResults(html string, expectedCount int) ([]Result, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, &ParseError{HTML: html}
+	}
+
+	var results []Result
+	doc.Find("a.item[href]").Each(func(_ int, s *goquery.Selection) {
+		href, ok := s.Attr("href")
+		if !ok {
+			return
+		}
+		title := strings.TrimSpace(s.Find("div.title").First().Text())
+		if title == "" {
+			return
+		}
+
+		var fields []string
+		s.Find("span").Each(func(_ int, span *goquery.Selection) {
+			if text := strings.TrimSpace(span.Text()); text != "" {
+				fields = append(fields, text)
+			}
+		})
+
+		results = append(results, Result{
+			Title: title,
+			Desc:  strings.Join(fields, "  "),
+			URL:   href,
+		})
+	})
+
+	if len(results) == 0 {
+		switch {
+		case looksBlocked(html):
+			return nil, ErrRateLimited
+		case expectedCount > 0:
+			// movhub itself reported results for this query, so the
+			// fragment's markup is what changed, not the result count.
+			return nil, &ParseError{HTML: html}
+		default:
+			return nil, ErrNoResults
+		}
+	}
+	return results, nil
+}
+
+// DetailFields is everything ParseDetail can pull off a movhub title page.
+type DetailFields struct {
+	Synopsis  string
+	Year      string
+	PosterURL string
+	Genres    []string
+	Cast      []string
+}
+
+// ParseDetail extracts the fields shown in the in-app detail view from a
+// movhub title page.
+func ParseDetail(html string) (DetailFields, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return DetailFields{}, &ParseError{HTML: html}
+	}
+
+	d := DetailFields{
+		Synopsis: strings.TrimSpace(doc.Find("div.description").First().Text()),
+		Year:     strings.TrimSpace(doc.Find("span.year").First().Text()),
+	}
+	if src, ok := doc.Find("img.poster").First().Attr("src"); ok {
+		d.PosterURL = src
+	}
+	doc.Find("div.genres a").Each(func(_ int, s *goquery.Selection) {
+		if text := strings.TrimSpace(s.Text()); text != "" {
+			d.Genres = append(d.Genres, text)
+		}
+	})
+	doc.Find("div.cast a").Each(func(_ int, s *goquery.Selection) {
+		if text := strings.TrimSpace(s.Text()); text != "" {
+			d.Cast = append(d.Cast, text)
+		}
+	})
+
+	if d.Synopsis == "" && d.Year == "" && d.PosterURL == "" && len(d.Genres) == 0 && len(d.Cast) == 0 {
+		return DetailFields{}, &ParseError{HTML: html}
+	}
+	return d, nil
+}
+
+// ParseEmbed extracts the first iframe src out of a movhub title page, the
+// third-party embed the extractor package pulls a direct stream out of.
+func ParseEmbed(html string) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return "", &ParseError{HTML: html}
+	}
+	src, ok := doc.Find("iframe[src]").First().Attr("src")
+	if !ok || src == "" {
+		return "", &ParseError{HTML: html}
+	}
+	return src, nil
+}
+
+// looksBlocked heuristically recognizes the handful of ways movhub tells
+// us to back off, so an empty result set from being rate-limited isn't
+// mistaken for a genuine "nothing matched".
+func looksBlocked(html string) bool {
+	lower := strings.ToLower(html)
+	return strings.Contains(lower, "too many requests") ||
+		strings.Contains(lower, "rate limit") ||
+		strings.Contains(lower, "captcha")
+}
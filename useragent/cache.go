@@ -0,0 +1,131 @@
+package useragent
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/JMJAJ/movcli/config"
+)
+
+// ttl bounds how long a fetched (or cached-on-disk) entry pool is trusted
+// before we pull a fresh one from caniuse.
+const ttl = 24 * time.Hour
+
+// cache holds the in-memory entry pool behind an RWMutex so concurrent
+// requests can all read it without contending, and a refreshing flag so
+// only one background goroutine ever fetches at a time.
+type cache struct {
+	mu         sync.RWMutex
+	entries    []Entry
+	expires    time.Time
+	refreshing bool
+}
+
+var shared = &cache{}
+
+// Prime kicks off the initial cache refresh in the background. Call it
+// once at startup so the caniuse download has a head start and the first
+// Pick() on the search hot path isn't the one waiting on it.
+func Prime() {
+	shared.refreshAsync()
+}
+
+// get returns the current entry pool without ever blocking on network
+// I/O. If nothing is cached yet, or the cache expired, it serves the
+// fallback (or stale) entries immediately and kicks a refresh off in the
+// background rather than fetching inline.
+func (c *cache) get() []Entry {
+	c.mu.RLock()
+	entries := c.entries
+	fresh := len(entries) > 0 && time.Now().Before(c.expires)
+	c.mu.RUnlock()
+
+	if fresh {
+		return entries
+	}
+
+	c.refreshAsync()
+	if len(entries) > 0 {
+		return entries
+	}
+	return fallbackEntries
+}
+
+// refreshAsync loads (or fetches) a fresh entry pool on a background
+// goroutine, unless one is already in flight.
+func (c *cache) refreshAsync() {
+	c.mu.Lock()
+	if c.refreshing {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing = true
+	c.mu.Unlock()
+
+	go func() {
+		entries, fetchedAt, err := loadDiskCache()
+		if err != nil || len(entries) == 0 {
+			entries, err = fetchCaniuse()
+			fetchedAt = time.Now()
+			if err == nil && len(entries) > 0 {
+				saveDiskCache(entries)
+			}
+		}
+		if len(entries) == 0 {
+			entries = fallbackEntries
+		}
+
+		c.mu.Lock()
+		c.entries = entries
+		c.expires = fetchedAt.Add(ttl)
+		c.refreshing = false
+		c.mu.Unlock()
+	}()
+}
+
+type diskCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Entries   []Entry   `json:"entries"`
+}
+
+func cachePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "useragent-cache.json"), nil
+}
+
+func loadDiskCache() ([]Entry, time.Time, error) {
+	p, err := cachePath()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var dc diskCache
+	if err := json.Unmarshal(data, &dc); err != nil {
+		return nil, time.Time{}, err
+	}
+	if time.Since(dc.FetchedAt) > ttl {
+		return nil, time.Time{}, nil
+	}
+	return dc.Entries, dc.FetchedAt, nil
+}
+
+func saveDiskCache(entries []Entry) {
+	p, err := cachePath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(diskCache{FetchedAt: time.Now(), Entries: entries}, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(p, data, 0o644)
+}
@@ -0,0 +1,75 @@
+package useragent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// caniuseURL is upstream caniuse's full dataset, which includes per-version
+// global usage share for every tracked browser.
+const caniuseURL = "https://raw.githubusercontent.com/Fyrd/caniuse/main/fulldata-json/data-2.0.json"
+
+// topN bounds how many (browser, version) entries we keep from the
+// dataset, favoring the highest-share versions.
+const topN = 12
+
+// caniuseAgentNames maps caniuse's own agent keys, e.g. "chrome", to the
+// browser name used in the generated headers.
+var caniuseAgentNames = map[string]string{
+	"chrome":  "Chrome",
+	"firefox": "Firefox",
+}
+
+type caniuseData struct {
+	Agents map[string]struct {
+		UsageGlobal map[string]float64 `json:"usage_global"`
+	} `json:"agents"`
+}
+
+// fetchCaniuse downloads caniuse's dataset and extracts the topN
+// (browser, version) combinations by real-world usage share.
+func fetchCaniuse() ([]Entry, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Get(caniuseURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var data caniuseData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for key, agent := range data.Agents {
+		name, ok := caniuseAgentNames[key]
+		if !ok {
+			continue
+		}
+		for version, share := range agent.UsageGlobal {
+			if share <= 0 {
+				continue
+			}
+			entries = append(entries, Entry{Browser: name, Version: version, Weight: share})
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("useragent: no usable entries in caniuse dataset")
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Weight > entries[j].Weight })
+	if len(entries) > topN {
+		entries = entries[:topN]
+	}
+	return entries, nil
+}
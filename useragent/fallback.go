@@ -0,0 +1,12 @@
+package useragent
+
+// fallbackEntries is used when the caniuse dataset can't be fetched and no
+// disk cache exists yet, so movcli still has a handful of realistic,
+// recent browser versions to rotate through rather than none at all.
+var fallbackEntries = []Entry{
+	{Browser: "Chrome", Version: "124.0.0.0", Weight: 1},
+	{Browser: "Chrome", Version: "123.0.0.0", Weight: 1},
+	{Browser: "Chrome", Version: "122.0.0.0", Weight: 1},
+	{Browser: "Firefox", Version: "124.0", Weight: 1},
+	{Browser: "Firefox", Version: "123.0", Weight: 1},
+}
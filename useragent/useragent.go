@@ -0,0 +1,85 @@
+// Package useragent rotates outbound HTTP headers across a pool of
+// realistic browser/OS combinations, weighted by real-world usage share,
+// so scraped sites see the same traffic mix a real audience would rather
+// than one static signature.
+package useragent
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Entry is one browser/version combination with its real-world usage
+// share, pulled from caniuse's usage_global data.
+type Entry struct {
+	Browser string  `json:"browser"`
+	Version string  `json:"version"`
+	Weight  float64 `json:"weight"`
+}
+
+// osPool is the small set of platforms a picked browser is paired with;
+// caniuse's dataset doesn't break share down by OS, so the OS is chosen
+// independently for each request.
+var osPool = []string{"Windows", "macOS", "Linux"}
+
+// Pick returns a full outbound header set (User-Agent and, for Chromium
+// browsers, the Sec-CH-UA client-hint triplet) for one request, weighted
+// by real-world share and paired with a randomly chosen OS.
+func Pick() map[string]string {
+	entries := shared.get()
+	e := weightedChoice(entries)
+	platform := osPool[rand.Intn(len(osPool))]
+	return headers(e, platform)
+}
+
+func weightedChoice(entries []Entry) Entry {
+	var total float64
+	for _, e := range entries {
+		total += e.Weight
+	}
+	if total <= 0 {
+		return entries[rand.Intn(len(entries))]
+	}
+	r := rand.Float64() * total
+	for _, e := range entries {
+		r -= e.Weight
+		if r <= 0 {
+			return e
+		}
+	}
+	return entries[len(entries)-1]
+}
+
+func headers(e Entry, platform string) map[string]string {
+	h := map[string]string{"User-Agent": uaString(e, platform)}
+	if e.Browser == "Chrome" {
+		major := e.Version
+		for i, c := range major {
+			if c == '.' {
+				major = major[:i]
+				break
+			}
+		}
+		h["Sec-CH-UA"] = fmt.Sprintf(`"Chromium";v="%s", "Not.A/Brand";v="8", "Google Chrome";v="%s"`, major, major)
+		h["Sec-CH-UA-Mobile"] = "?0"
+		h["Sec-CH-UA-Platform"] = fmt.Sprintf("%q", platform)
+	}
+	return h
+}
+
+func uaString(e Entry, platform string) string {
+	var os string
+	switch platform {
+	case "macOS":
+		os = "Macintosh; Intel Mac OS X 10_15_7"
+	case "Linux":
+		os = "X11; Linux x86_64"
+	default:
+		os = "Windows NT 10.0; Win64; x64"
+	}
+
+	if e.Browser == "Firefox" {
+		return fmt.Sprintf("Mozilla/5.0 (%s; rv:%s) Gecko/20100101 Firefox/%s", os, e.Version, e.Version)
+	}
+	return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s Safari/537.36", os, e.Version)
+}